@@ -2,73 +2,119 @@ package main
 
 import (
 	"bufio"
-	"fmt"
+	"container/heap"
 	"math"
 	"os"
+
+	"github.com/Shipovmax/CodeRun-and-Practice/Code/Home/Homework_3/internal/fastio"
 )
 
-func min(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
+// edge — взвешенное ребро дерева: сосед и стоимость перехода к нему.
+type edge struct {
+	to int
+	w  int64
 }
 
-func main() {
-	in := bufio.NewReader(os.Stdin)
-	out := bufio.NewWriter(os.Stdout)
-	defer out.Flush()
+// frontierItem — запись в куче фронта многоисточникового Дейкстры:
+// расстояние от листа-владельца owner до узла node.
+type frontierItem struct {
+	dist  int64
+	owner int
+	node  int
+}
 
-	var n int
-	if _, err := fmt.Fscan(in, &n); err != nil {
-		return
-	}
+type frontierHeap []frontierItem
 
-	g := make([][]int, n+1)
-	deg := make([]int, n+1)
-	for i := 0; i < n-1; i++ {
-		var a, b int
-		fmt.Fscan(in, &a, &b)
-		g[a] = append(g[a], b)
-		g[b] = append(g[b], a)
-		deg[a]++
-		deg[b]++
-	}
+func (h frontierHeap) Len() int            { return len(h) }
+func (h frontierHeap) Less(i, j int) bool  { return h[i].dist < h[j].dist }
+func (h frontierHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *frontierHeap) Push(x interface{}) { *h = append(*h, x.(frontierItem)) }
+func (h *frontierHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	it := old[n-1]
+	*h = old[:n-1]
+	return it
+}
 
-	// соберём листья (degree == 1)
-	owner := make([]int, n+1) // какой лист "владеет" вершиной
-	dist := make([]int, n+1)  // расстояние до владельца
-	q := make([]int, 0, n)
+// shortestLeafPair запускает многоисточниковый Дейкстру от всех листьев
+// взвешенного дерева g (каждый лист — сам себе владелец на старте) и
+// возвращает минимальное расстояние между двумя листьями. deg[v] — степень
+// вершины v, по ней определяются листья (deg[v] == 1).
+func shortestLeafPair(g [][]edge, deg []int) int64 {
+	n := len(g) - 1
+	owner := make([]int, n+1)
+	dist := make([]int64, n+1)
+	claimed := make([]bool, n+1)
 
+	fh := &frontierHeap{}
 	for v := 1; v <= n; v++ {
 		if deg[v] == 1 { // лист
-			owner[v] = v
-			dist[v] = 0
-			q = append(q, v)
+			heap.Push(fh, frontierItem{dist: 0, owner: v, node: v})
 		}
 	}
 
-	ans := math.MaxInt32
-	head := 0
-	for head < len(q) {
-		u := q[head]
-		head++
-		for _, v := range g[u] {
-			if owner[v] == 0 {
-				owner[v] = owner[u]
-				dist[v] = dist[u] + 1
-				q = append(q, v)
-			} else if owner[v] != owner[u] {
-				// волны от разных листьев встретились по ребру u-v
-				cand := dist[u] + dist[v] + 1
-				ans = min(ans, cand)
+	var ans int64 = math.MaxInt64
+	for fh.Len() > 0 {
+		it := heap.Pop(fh).(frontierItem)
+
+		if claimed[it.node] {
+			// Вершина уже занята первой волной: если вторая волна пришла от
+			// другого владельца, листья встретились ровно в этой вершине.
+			if owner[it.node] != it.owner {
+				if cand := it.dist + dist[it.node]; cand < ans {
+					ans = cand
+				}
+			}
+			continue
+		}
+
+		claimed[it.node] = true
+		owner[it.node] = it.owner
+		dist[it.node] = it.dist
+
+		for _, e := range g[it.node] {
+			if !claimed[e.to] {
+				heap.Push(fh, frontierItem{dist: it.dist + e.w, owner: it.owner, node: e.to})
+			} else if owner[e.to] != it.owner {
+				// Волны от разных листьев встретились по ребру it.node-e.to.
+				if cand := it.dist + e.w + dist[e.to]; cand < ans {
+					ans = cand
+				}
 			}
 		}
 	}
 
-	// ans обязательно установлен (в дереве >=2 листа), но на всякий случай:
-	if ans == math.MaxInt32 {
+	if ans == math.MaxInt64 {
 		ans = 0
 	}
-	fmt.Fprintln(out, ans)
+	return ans
+}
+
+func main() {
+	in := fastio.NewReader(os.Stdin)
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+
+	n := in.ReadInt()
+	if n == 0 {
+		return
+	}
+
+	// Граф по-прежнему невзвешенный (таков формат ввода задачи) — вес 1 на
+	// каждое ребро, shortestLeafPair просто обобщена для случаев, когда он
+	// может быть и другим.
+	g := make([][]edge, n+1)
+	deg := make([]int, n+1)
+	for i := 0; i < n-1; i++ {
+		a := in.ReadInt()
+		b := in.ReadInt()
+		g[a] = append(g[a], edge{to: b, w: 1})
+		g[b] = append(g[b], edge{to: a, w: 1})
+		deg[a]++
+		deg[b]++
+	}
+
+	fastio.WriteInt(out, shortestLeafPair(g, deg))
+	out.WriteByte('\n')
 }
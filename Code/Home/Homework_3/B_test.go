@@ -0,0 +1,60 @@
+package main
+
+import "testing"
+
+func TestShortestLeafPairEdgeMeeting(t *testing.T) {
+	// Путь 1-2-3-4: листья 1 и 4, встречаются на ребре 2-3.
+	g := make([][]edge, 5)
+	deg := make([]int, 5)
+	addEdge := func(a, b int, w int64) {
+		g[a] = append(g[a], edge{to: b, w: w})
+		g[b] = append(g[b], edge{to: a, w: w})
+		deg[a]++
+		deg[b]++
+	}
+	addEdge(1, 2, 1)
+	addEdge(2, 3, 1)
+	addEdge(3, 4, 1)
+
+	if got, want := shortestLeafPair(g, deg), int64(3); got != want {
+		t.Fatalf("shortestLeafPair() = %d, want %d", got, want)
+	}
+}
+
+func TestShortestLeafPairVertexMeeting(t *testing.T) {
+	// Звезда с центром 1 и тремя листьями 2, 3, 4: ближайшая пара листьев
+	// встречается в центре.
+	g := make([][]edge, 5)
+	deg := make([]int, 5)
+	addEdge := func(a, b int, w int64) {
+		g[a] = append(g[a], edge{to: b, w: w})
+		g[b] = append(g[b], edge{to: a, w: w})
+		deg[a]++
+		deg[b]++
+	}
+	addEdge(1, 2, 1)
+	addEdge(1, 3, 1)
+	addEdge(1, 4, 1)
+
+	if got, want := shortestLeafPair(g, deg), int64(2); got != want {
+		t.Fatalf("shortestLeafPair() = %d, want %d", got, want)
+	}
+}
+
+func TestShortestLeafPairWeightedEdges(t *testing.T) {
+	// 1 --5-- 2 --1-- 3: кратчайшая пара листьев идёт через лёгкое ребро.
+	g := make([][]edge, 4)
+	deg := make([]int, 4)
+	addEdge := func(a, b int, w int64) {
+		g[a] = append(g[a], edge{to: b, w: w})
+		g[b] = append(g[b], edge{to: a, w: w})
+		deg[a]++
+		deg[b]++
+	}
+	addEdge(1, 2, 5)
+	addEdge(2, 3, 1)
+
+	if got, want := shortestLeafPair(g, deg), int64(6); got != want {
+		t.Fatalf("shortestLeafPair() = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,87 @@
+// Package fastio даёт быстрый побайтовый ввод/вывод целых чисел для задач,
+// где n доходит до миллионов и fmt.Fscan становится узким местом.
+package fastio
+
+import (
+	"bufio"
+	"io"
+)
+
+// Reader читает целые числа напрямую из io.Reader через собственный 4 КБ
+// буфер, без аллокаций bufio.Scanner и без рефлексии fmt.Fscan.
+type Reader struct {
+	r   io.Reader
+	buf [4096]byte
+	pos int
+	sz  int
+}
+
+// NewReader оборачивает r (обычно os.Stdin) в быстрый побайтовый читатель.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: r}
+}
+
+func (rd *Reader) readByte() (byte, bool) {
+	if rd.pos == rd.sz {
+		n, err := rd.r.Read(rd.buf[:])
+		if n == 0 {
+			return 0, false
+		}
+		rd.sz = n
+		rd.pos = 0
+		_ = err
+	}
+	b := rd.buf[rd.pos]
+	rd.pos++
+	return b, true
+}
+
+// ReadInt пропускает всё, кроме цифр и ведущего минуса, и считывает
+// следующее целое число. На EOF без числа возвращает 0.
+func (rd *Reader) ReadInt() int {
+	b, ok := rd.readByte()
+	for ok && b != '-' && (b < '0' || b > '9') {
+		b, ok = rd.readByte()
+	}
+	if !ok {
+		return 0
+	}
+
+	neg := false
+	if b == '-' {
+		neg = true
+		b, ok = rd.readByte()
+	}
+
+	x := 0
+	for ok && b >= '0' && b <= '9' {
+		x = x*10 + int(b-'0')
+		b, ok = rd.readByte()
+	}
+
+	if neg {
+		x = -x
+	}
+	return x
+}
+
+// WriteInt форматирует x в w вручную, без fmt.Fprint.
+func WriteInt(w *bufio.Writer, x int64) {
+	if x < 0 {
+		w.WriteByte('-')
+		x = -x
+	}
+	if x == 0 {
+		w.WriteByte('0')
+		return
+	}
+
+	var buf [20]byte
+	i := len(buf)
+	for x > 0 {
+		i--
+		buf[i] = byte('0' + x%10)
+		x /= 10
+	}
+	w.Write(buf[i:])
+}
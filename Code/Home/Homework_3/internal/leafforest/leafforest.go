@@ -0,0 +1,340 @@
+// Package leafforest — онлайн-версия задачи о ближайшей паре листьев:
+// деревья можно обрезать по листу и сращивать новым ребром, а
+// MinLeafPairDistance отвечает без полного пересчёта BFS после каждой
+// операции.
+package leafforest
+
+import (
+	"container/heap"
+	"math"
+)
+
+const infDist = math.MaxInt32
+
+// LeafForest хранит лес деревьев с операциями обрезки листа, сращивания
+// поддеревьев и запроса ближайшей пары листьев.
+type LeafForest struct {
+	n          int
+	g          [][]int
+	deg        []int
+	alive      []bool
+	parent     []int
+	compSize   []int
+	candidates [][]int
+	prob       []float64
+
+	owner   []int // ближайший лист-владелец каждой вершины (0 — ещё не определён)
+	dist    []int // расстояние до этого владельца
+	ownedBy [][]int // ownedBy[leaf] — вершины, которыми сейчас владеет leaf
+
+	meetHeap *meetHeap
+}
+
+// NewLeafForest создаёт лес из n изолированных вершин (1..n), каждая — сама
+// себе компонента и сама себе гарантированный лидер (Probability == 1).
+// Рёбер пока нет, поэтому листьев (degree == 1) тоже нет.
+func NewLeafForest(n int) *LeafForest {
+	f := &LeafForest{
+		n:          n,
+		g:          make([][]int, n+1),
+		deg:        make([]int, n+1),
+		alive:      make([]bool, n+1),
+		parent:     make([]int, n+1),
+		compSize:   make([]int, n+1),
+		candidates: make([][]int, n+1),
+		prob:       make([]float64, n+1),
+		owner:      make([]int, n+1),
+		dist:       make([]int, n+1),
+		ownedBy:    make([][]int, n+1),
+		meetHeap:   &meetHeap{},
+	}
+	for v := 1; v <= n; v++ {
+		f.alive[v] = true
+		f.parent[v] = v
+		f.compSize[v] = 1
+		f.candidates[v] = []int{v}
+		f.prob[v] = 1
+		f.dist[v] = infDist
+	}
+	return f
+}
+
+func (f *LeafForest) find(x int) int {
+	for f.parent[x] != x {
+		f.parent[x] = f.parent[f.parent[x]]
+		x = f.parent[x]
+	}
+	return x
+}
+
+// relaxItem — кандидат на улучшение owner/dist вершины node волной, идущей
+// от листа owner.
+type relaxItem struct {
+	dist  int
+	owner int
+	node  int
+}
+
+type relaxHeap []relaxItem
+
+func (h relaxHeap) Len() int            { return len(h) }
+func (h relaxHeap) Less(i, j int) bool  { return h[i].dist < h[j].dist }
+func (h relaxHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *relaxHeap) Push(x interface{}) { *h = append(*h, x.(relaxItem)) }
+func (h *relaxHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	it := old[n-1]
+	*h = old[:n-1]
+	return it
+}
+
+// meetCandidate — зафиксированное на момент вставки расстояние между двумя
+// листьями u и v. Остаётся в куче, пока не будет извлечено и проверено на
+// актуальность в MinLeafPairDistance.
+type meetCandidate struct {
+	dist int
+	u, v int
+}
+
+type meetHeap []meetCandidate
+
+func (h meetHeap) Len() int            { return len(h) }
+func (h meetHeap) Less(i, j int) bool  { return h[i].dist < h[j].dist }
+func (h meetHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *meetHeap) Push(x interface{}) { *h = append(*h, x.(meetCandidate)) }
+func (h *meetHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	it := old[n-1]
+	*h = old[:n-1]
+	return it
+}
+
+// setOwner переносит node из списка владений старого владельца в список
+// нового и обновляет owner/dist.
+func (f *LeafForest) setOwner(node, owner, dist int) {
+	if old := f.owner[node]; old != 0 {
+		f.removeOwned(old, node)
+	}
+	f.owner[node] = owner
+	f.dist[node] = dist
+	f.ownedBy[owner] = append(f.ownedBy[owner], node)
+}
+
+func (f *LeafForest) removeOwned(owner, node int) {
+	list := f.ownedBy[owner]
+	for i, w := range list {
+		if w == node {
+			list[i] = list[len(list)-1]
+			f.ownedBy[owner] = list[:len(list)-1]
+			return
+		}
+	}
+}
+
+// pushMeet регистрирует, что волны owner и other встретились на суммарном
+// расстоянии dist. Кандидат проверяется на актуальность лениво, при чтении.
+func (f *LeafForest) pushMeet(owner, other, dist int) {
+	if owner == other {
+		return
+	}
+	heap.Push(f.meetHeap, meetCandidate{dist: dist, u: owner, v: other})
+}
+
+// repair прогоняет из seeds многоисточниковую релаксацию Дейкстры поверх уже
+// накопленного состояния owner/dist: улучшает расстояния там, где это
+// возможно, и при столкновении двух разных владельцев кладёт кандидата в
+// meetHeap. Это локальный ремонт состояния, а не пересчёт BFS по всему лесу.
+func (f *LeafForest) repair(seeds []relaxItem) {
+	pq := &relaxHeap{}
+	for _, s := range seeds {
+		heap.Push(pq, s)
+	}
+	for pq.Len() > 0 {
+		it := heap.Pop(pq).(relaxItem)
+		cur := f.dist[it.node]
+		if it.dist < cur {
+			f.setOwner(it.node, it.owner, it.dist)
+			for _, w := range f.g[it.node] {
+				if f.alive[w] {
+					heap.Push(pq, relaxItem{dist: it.dist + 1, owner: it.owner, node: w})
+				}
+			}
+		} else if f.owner[it.node] != 0 && f.owner[it.node] != it.owner {
+			f.pushMeet(it.owner, f.owner[it.node], it.dist+cur)
+		}
+	}
+}
+
+// orphan strips v of its owner and invalidates everyone v currently owns
+// (v can no longer serve as anyone's nearest leaf), returning the list of
+// vertices that need a new owner via repair.
+func (f *LeafForest) orphan(v int) []int {
+	orphans := f.ownedBy[v]
+	f.ownedBy[v] = nil
+	for _, w := range orphans {
+		if w != v {
+			f.owner[w] = 0
+			f.dist[w] = infDist
+		}
+	}
+	f.owner[v] = 0
+	f.dist[v] = infDist
+	return orphans
+}
+
+// reseedFromNeighbors queues node for repair using whichever already-valid
+// neighbors of node can currently offer it an owner/dist.
+func (f *LeafForest) reseedFromNeighbors(node int, seeds []relaxItem) []relaxItem {
+	for _, w := range f.g[node] {
+		if f.alive[w] && f.owner[w] != 0 {
+			seeds = append(seeds, relaxItem{dist: f.dist[w] + 1, owner: f.owner[w], node: node})
+		}
+	}
+	return seeds
+}
+
+// PruneLeaf удаляет вершину v, если она сейчас жива и является листом
+// (deg[v] == 1). Сосед, чья степень падает до 1, становится новым листом;
+// вершины, которыми владел v, теряют владельца и получают новый через
+// локальный repair от ближайших ещё валидных соседей.
+func (f *LeafForest) PruneLeaf(v int) {
+	if !f.alive[v] || f.deg[v] != 1 {
+		return
+	}
+	f.alive[v] = false
+
+	newLeaves := make(map[int]bool)
+	for _, to := range f.g[v] {
+		if f.alive[to] {
+			f.deg[to]--
+			if f.deg[to] == 1 {
+				newLeaves[to] = true
+			}
+		}
+	}
+
+	orphans := f.orphan(v)
+
+	var seeds []relaxItem
+	for to := range newLeaves {
+		// Seed only — let repair() itself call setOwner on first pop, so its
+		// "strictly improves" check fires and propagates to to's other
+		// still-alive neighbors. Pre-assigning owner/dist here would make
+		// that check a no-op and silently drop that propagation.
+		seeds = append(seeds, relaxItem{dist: 0, owner: to, node: to})
+	}
+	for _, w := range orphans {
+		if w == v || newLeaves[w] || !f.alive[w] {
+			continue
+		}
+		seeds = f.reseedFromNeighbors(w, seeds)
+	}
+
+	f.repair(seeds)
+}
+
+// MergeSubtrees добавляет ребро u-v, сращивая их компоненты. Для
+// union-by-size-лидерства это проигрыш/выигрыш компоненты целиком (см.
+// Probability); для расстояний же новое ребро лишь локально "подпитывает"
+// repair — эндпойнты ребра и любые вершины, у которых из-за этого ребра
+// поменялся статус листа.
+func (f *LeafForest) MergeSubtrees(u, v int) {
+	f.g[u] = append(f.g[u], v)
+	f.g[v] = append(f.g[v], u)
+
+	var seeds []relaxItem
+	seeds = f.bumpDegree(u, seeds)
+	seeds = f.bumpDegree(v, seeds)
+
+	if f.dist[u] != infDist {
+		seeds = append(seeds, relaxItem{dist: f.dist[u] + 1, owner: f.owner[u], node: v})
+	}
+	if f.dist[v] != infDist {
+		seeds = append(seeds, relaxItem{dist: f.dist[v] + 1, owner: f.owner[v], node: u})
+	}
+	f.repair(seeds)
+
+	ru, rv := f.find(u), f.find(v)
+	if ru == rv {
+		return
+	}
+
+	sa, sb := f.compSize[ru], f.compSize[rv]
+	switch {
+	case sa > sb:
+		f.dropCandidates(rv)
+		f.parent[rv] = ru
+		f.compSize[ru] += sb
+	case sa < sb:
+		f.dropCandidates(ru)
+		f.parent[ru] = rv
+		f.compSize[rv] += sa
+	default:
+		for _, c := range f.candidates[ru] {
+			f.prob[c] *= 0.5
+		}
+		for _, c := range f.candidates[rv] {
+			f.prob[c] *= 0.5
+		}
+		f.candidates[rv] = append(f.candidates[rv], f.candidates[ru]...)
+		f.candidates[ru] = nil
+		f.parent[ru] = rv
+		f.compSize[rv] += sa
+	}
+}
+
+// bumpDegree учитывает рост степени вершины v на единицу: если v только что
+// стал листом — сажает его как нового владельца дистанции 0; если, наоборот,
+// только что перестал им быть — просит repair переопределить его owner/dist
+// от ещё живых соседей.
+func (f *LeafForest) bumpDegree(v int, seeds []relaxItem) []relaxItem {
+	f.deg[v]++
+	switch f.deg[v] {
+	case 1:
+		// Seed only (see the matching comment in PruneLeaf) — repair() must
+		// be the one to call setOwner so it also propagates to v's neighbor.
+		seeds = append(seeds, relaxItem{dist: 0, owner: v, node: v})
+	case 2:
+		orphans := f.orphan(v)
+		for _, w := range orphans {
+			if w != v && f.alive[w] {
+				seeds = f.reseedFromNeighbors(w, seeds)
+			}
+		}
+		seeds = f.reseedFromNeighbors(v, seeds)
+	}
+	return seeds
+}
+
+// dropCandidates обнуляет вероятность всех кандидатов проигравшей компоненты:
+// они больше никогда не станут лидером итоговой компоненты.
+func (f *LeafForest) dropCandidates(loser int) {
+	for _, c := range f.candidates[loser] {
+		f.prob[c] = 0
+	}
+	f.candidates[loser] = nil
+}
+
+// Probability возвращает вероятность того, что v останется "лидером" своей
+// компоненты после всех уже сыгранных MergeSubtrees, при условии правила
+// union-by-size со случайным тай-брейком 50/50 при равенстве размеров.
+func (f *LeafForest) Probability(v int) float64 {
+	return f.prob[v]
+}
+
+// MinLeafPairDistance возвращает кратчайшее расстояние между двумя живыми
+// листьями по текущему состоянию леса. Верхушка meetHeap лениво проверяется
+// на актуальность (оба конца всё ещё живые листья) и устаревшие записи
+// выбрасываются насовсем — без единого BFS-прохода по графу.
+func (f *LeafForest) MinLeafPairDistance() int {
+	for f.meetHeap.Len() > 0 {
+		top := (*f.meetHeap)[0]
+		if f.alive[top.u] && f.deg[top.u] == 1 && f.alive[top.v] && f.deg[top.v] == 1 {
+			return top.dist
+		}
+		heap.Pop(f.meetHeap)
+	}
+	return 0
+}
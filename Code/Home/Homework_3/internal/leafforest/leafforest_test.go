@@ -0,0 +1,186 @@
+package leafforest
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestMinLeafPairDistancePath(t *testing.T) {
+	f := NewLeafForest(5)
+	f.MergeSubtrees(1, 2)
+	f.MergeSubtrees(2, 3)
+	f.MergeSubtrees(3, 4)
+	f.MergeSubtrees(4, 5)
+
+	if got, want := f.MinLeafPairDistance(), 4; got != want {
+		t.Fatalf("MinLeafPairDistance() = %d, want %d", got, want)
+	}
+}
+
+func TestMinLeafPairDistanceAfterPrune(t *testing.T) {
+	f := NewLeafForest(5)
+	f.MergeSubtrees(1, 2)
+	f.MergeSubtrees(2, 3)
+	f.MergeSubtrees(3, 4)
+	f.MergeSubtrees(4, 5)
+
+	f.PruneLeaf(1) // 2 becomes the new leaf on that side
+
+	if got, want := f.MinLeafPairDistance(), 3; got != want {
+		t.Fatalf("MinLeafPairDistance() after prune = %d, want %d", got, want)
+	}
+}
+
+func TestMinLeafPairDistanceStar(t *testing.T) {
+	f := NewLeafForest(4)
+	f.MergeSubtrees(1, 2)
+	f.MergeSubtrees(1, 3)
+	f.MergeSubtrees(1, 4)
+
+	if got, want := f.MinLeafPairDistance(), 2; got != want {
+		t.Fatalf("MinLeafPairDistance() = %d, want %d", got, want)
+	}
+}
+
+func TestProbabilitySumsToOne(t *testing.T) {
+	f := NewLeafForest(4)
+	f.MergeSubtrees(1, 2)
+	f.MergeSubtrees(3, 4)
+	f.MergeSubtrees(1, 3)
+
+	var sum float64
+	for v := 1; v <= 4; v++ {
+		sum += f.Probability(v)
+	}
+	if sum < 0.999 || sum > 1.001 {
+		t.Fatalf("probabilities sum to %f, want 1", sum)
+	}
+}
+
+func TestMinLeafPairDistancePrunedLeafReachesRemainingNeighbor(t *testing.T) {
+	// Path 3-2-1: pruning leaf 3 promotes 2 to a leaf, and the remaining
+	// meeting candidate must still be reachable through 2's other edge (to
+	// 1), not just 2's self-seed.
+	f := NewLeafForest(3)
+	f.MergeSubtrees(3, 2)
+	f.MergeSubtrees(2, 1)
+
+	f.PruneLeaf(3)
+
+	if got, want := f.MinLeafPairDistance(), 1; got != want {
+		t.Fatalf("MinLeafPairDistance() = %d, want %d", got, want)
+	}
+}
+
+func TestMinLeafPairDistanceMatchesOracle(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for trial := 0; trial < 200; trial++ {
+		n := 2 + rng.Intn(10)
+		f := NewLeafForest(n)
+		g := make([][]int, n+1)
+		alive := make([]bool, n+1)
+		for v := 1; v <= n; v++ {
+			alive[v] = true
+		}
+
+		connected := []int{1}
+		for v := 2; v <= n; v++ {
+			u := connected[rng.Intn(len(connected))]
+			f.MergeSubtrees(u, v)
+			g[u] = append(g[u], v)
+			g[v] = append(g[v], u)
+			connected = append(connected, v)
+		}
+
+		ops := rng.Intn(n)
+		for i := 0; i < ops; i++ {
+			leaves := make([]int, 0, n)
+			for v := 1; v <= n; v++ {
+				if alive[v] && aliveDeg(g, alive, v) == 1 {
+					leaves = append(leaves, v)
+				}
+			}
+			if len(leaves) == 0 {
+				break
+			}
+			v := leaves[rng.Intn(len(leaves))]
+			f.PruneLeaf(v)
+			alive[v] = false
+		}
+
+		got := f.MinLeafPairDistance()
+		want := bfsOracle(g, alive, n)
+		if got != want {
+			t.Fatalf("trial %d: MinLeafPairDistance() = %d, want %d (oracle)", trial, got, want)
+		}
+	}
+}
+
+func aliveDeg(g [][]int, alive []bool, v int) int {
+	d := 0
+	for _, w := range g[v] {
+		if alive[w] {
+			d++
+		}
+	}
+	return d
+}
+
+// bfsOracle recomputes the shortest leaf-to-leaf distance from scratch, used
+// only to check LeafForest's incremental answer in tests.
+func bfsOracle(g [][]int, alive []bool, n int) int {
+	owner := make([]int, n+1)
+	dist := make([]int, n+1)
+	claimed := make([]bool, n+1)
+	q := make([]int, 0, n)
+
+	for v := 1; v <= n; v++ {
+		if alive[v] && aliveDeg(g, alive, v) == 1 {
+			owner[v] = v
+			claimed[v] = true
+			q = append(q, v)
+		}
+	}
+
+	ans := math.MaxInt32
+	head := 0
+	for head < len(q) {
+		u := q[head]
+		head++
+		for _, w := range g[u] {
+			if !alive[w] {
+				continue
+			}
+			if !claimed[w] {
+				claimed[w] = true
+				owner[w] = owner[u]
+				dist[w] = dist[u] + 1
+				q = append(q, w)
+			} else if owner[w] != owner[u] {
+				if cand := dist[u] + dist[w] + 1; cand < ans {
+					ans = cand
+				}
+			}
+		}
+	}
+
+	if ans == math.MaxInt32 {
+		return 0
+	}
+	return ans
+}
+
+func TestProbabilityWinnerTakesAll(t *testing.T) {
+	f := NewLeafForest(3)
+	f.MergeSubtrees(1, 2) // equal sizes: 1 and 2 each get 0.5
+	f.MergeSubtrees(1, 3) // component {1,2} (size 2) beats singleton {3} (size 1)
+
+	if f.Probability(3) != 0 {
+		t.Fatalf("Probability(3) = %f, want 0 (lost to the larger component)", f.Probability(3))
+	}
+	if got := f.Probability(1) + f.Probability(2); got < 0.999 || got > 1.001 {
+		t.Fatalf("Probability(1)+Probability(2) = %f, want 1", got)
+	}
+}
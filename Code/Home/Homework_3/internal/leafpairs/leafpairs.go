@@ -0,0 +1,111 @@
+// Package leafpairs ищет k кратчайших различных пар листьев в невзвешенном
+// дереве — обобщение одноответной задачи о ближайшей паре листьев.
+package leafpairs
+
+import "container/heap"
+
+// Result — один кандидат из KShortestLeafPairs: пара листьев U, V и
+// расстояние между ними.
+type Result struct {
+	U, V int
+	Dist int64
+}
+
+// resultHeap — min-куча кандидатов Result по полю Dist.
+type resultHeap []Result
+
+func (h resultHeap) Len() int            { return len(h) }
+func (h resultHeap) Less(i, j int) bool  { return h[i].Dist < h[j].Dist }
+func (h resultHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *resultHeap) Push(x interface{}) { *h = append(*h, x.(Result)) }
+func (h *resultHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	it := old[n-1]
+	*h = old[:n-1]
+	return it
+}
+
+func canonPair(a, b int) [2]int {
+	if a > b {
+		a, b = b, a
+	}
+	return [2]int{a, b}
+}
+
+// arrival — запись о том, что волна от листа owner дошла до вершины за dist шагов.
+type arrival struct {
+	owner int
+	dist  int
+}
+
+func hasOwner(as []arrival, owner int) bool {
+	for _, a := range as {
+		if a.owner == owner {
+			return true
+		}
+	}
+	return false
+}
+
+// KShortestLeafPairs возвращает k глобально кратчайших различных пар листьев
+// невзвешенного дерева из n вершин, заданного списком рёбер edges
+// (1-индексация). Каждая вершина хранит до k приходов фронта (по одному на
+// владеющий лист), и любые два прихода от разных листьев дают кандидата —
+// обобщение проверки "волны встретились по ребру" из одноответного решения.
+func KShortestLeafPairs(n int, edges [][2]int, k int) []Result {
+	g := make([][]int, n+1)
+	deg := make([]int, n+1)
+	for _, e := range edges {
+		a, b := e[0], e[1]
+		g[a] = append(g[a], b)
+		g[b] = append(g[b], a)
+		deg[a]++
+		deg[b]++
+	}
+
+	type queued struct {
+		node, owner, dist int
+	}
+
+	arrivals := make([][]arrival, n+1)
+	q := make([]queued, 0, n)
+	for v := 1; v <= n; v++ {
+		if deg[v] == 1 { // лист
+			arrivals[v] = append(arrivals[v], arrival{owner: v, dist: 0})
+			q = append(q, queued{node: v, owner: v, dist: 0})
+		}
+	}
+
+	seen := make(map[[2]int]bool)
+	ch := &resultHeap{}
+
+	head := 0
+	for head < len(q) {
+		cur := q[head]
+		head++
+		for _, to := range g[cur.node] {
+			for _, a := range arrivals[to] {
+				if a.owner == cur.owner {
+					continue
+				}
+				pair := canonPair(a.owner, cur.owner)
+				if seen[pair] {
+					continue
+				}
+				seen[pair] = true
+				heap.Push(ch, Result{U: pair[0], V: pair[1], Dist: int64(a.dist + cur.dist + 1)})
+			}
+			if len(arrivals[to]) < k && !hasOwner(arrivals[to], cur.owner) {
+				arrivals[to] = append(arrivals[to], arrival{owner: cur.owner, dist: cur.dist + 1})
+				q = append(q, queued{node: to, owner: cur.owner, dist: cur.dist + 1})
+			}
+		}
+	}
+
+	res := make([]Result, 0, k)
+	for ch.Len() > 0 && len(res) < k {
+		res = append(res, heap.Pop(ch).(Result))
+	}
+	return res
+}
@@ -0,0 +1,56 @@
+package leafpairs
+
+import "testing"
+
+func TestKShortestLeafPairsStar(t *testing.T) {
+	// Звезда: центр 1, листья 2,3,4,5 — все пары равноудалены (через центр).
+	edges := [][2]int{{1, 2}, {1, 3}, {1, 4}, {1, 5}}
+
+	res := KShortestLeafPairs(5, edges, 3)
+	if len(res) != 3 {
+		t.Fatalf("len(res) = %d, want 3", len(res))
+	}
+	for _, r := range res {
+		if r.Dist != 2 {
+			t.Errorf("Result{%d,%d} has Dist %d, want 2", r.U, r.V, r.Dist)
+		}
+	}
+}
+
+func TestKShortestLeafPairsDistinctDistances(t *testing.T) {
+	//      3
+	//      |
+	// 1 -- 2 -- 4 -- 5
+	//           |
+	//           6
+	// Листья: 1, 3, 5, 6. Ближайшие пары: (1,3) и (5,6), обе на расстоянии 2.
+	edges := [][2]int{{1, 2}, {2, 3}, {2, 4}, {4, 5}, {4, 6}}
+
+	res := KShortestLeafPairs(6, edges, 2)
+	if len(res) != 2 {
+		t.Fatalf("len(res) = %d, want 2", len(res))
+	}
+
+	seen := make(map[[2]int]bool)
+	for _, r := range res {
+		if r.Dist != 2 {
+			t.Errorf("Result{%d,%d} has Dist %d, want 2", r.U, r.V, r.Dist)
+		}
+		seen[canonPair(r.U, r.V)] = true
+	}
+
+	for _, want := range [][2]int{{1, 3}, {5, 6}} {
+		if !seen[want] {
+			t.Errorf("missing expected pair %v in %v", want, res)
+		}
+	}
+}
+
+func TestKShortestLeafPairsCapsAtK(t *testing.T) {
+	edges := [][2]int{{1, 2}, {1, 3}, {1, 4}, {1, 5}}
+
+	res := KShortestLeafPairs(5, edges, 1)
+	if len(res) != 1 {
+		t.Fatalf("len(res) = %d, want 1", len(res))
+	}
+}
@@ -0,0 +1,97 @@
+// Package xortrie ищет пару листьев дерева с минимальным XOR их меток через
+// бинарный бор — компаньон к поиску ближайших листьев по расстоянию в
+// графе.
+package xortrie
+
+import "math"
+
+const labelBits = 64
+
+// node — узел бинарного бора, построенного по 64-битным меткам листьев, бит
+// за битом от старшего к младшему. leafID хранит id любого листа, чей путь
+// проходит через этот узел (-1, если узел ещё пуст), и используется только
+// как представитель для ответа.
+type node struct {
+	children [2]*node
+	leafID   int
+}
+
+func newNode() *node {
+	return &node{leafID: -1}
+}
+
+func (t *node) insert(label uint64, leaf int) {
+	cur := t
+	cur.leafID = leaf
+	for b := labelBits - 1; b >= 0; b-- {
+		bit := (label >> uint(b)) & 1
+		if cur.children[bit] == nil {
+			cur.children[bit] = newNode()
+		}
+		cur = cur.children[bit]
+		cur.leafID = leaf
+	}
+}
+
+// query жадно спускается по бору, на каждом шаге предпочитая тот же бит,
+// что и у label (это минимизирует XOR), и уходит в противоположную ветку
+// только если предпочитаемой нет. Возвращает -1, если бор пуст.
+func (t *node) query(label uint64) int {
+	cur := t
+	if cur.leafID == -1 {
+		return -1
+	}
+	for b := labelBits - 1; b >= 0; b-- {
+		bit := (label >> uint(b)) & 1
+		switch {
+		case cur.children[bit] != nil:
+			cur = cur.children[bit]
+		case cur.children[1-bit] != nil:
+			cur = cur.children[1-bit]
+		default:
+			return cur.leafID
+		}
+	}
+	return cur.leafID
+}
+
+// MinXORLeafPair возвращает пару листьев дерева из n вершин (edges,
+// 1-индексация), чьи метки label дают минимальный XOR, вместе со значением
+// этого XOR. Листья добавляются в бор по одному, и перед вставкой каждый
+// запрашивает минимальный XOR среди уже вставленных — классический приём
+// "минимальная пара по XOR через бор".
+func MinXORLeafPair(n int, edges [][2]int, label []uint64) (a, b int, xor uint64) {
+	g := make([][]int, n+1)
+	deg := make([]int, n+1)
+	for _, e := range edges {
+		x, y := e[0], e[1]
+		g[x] = append(g[x], y)
+		g[y] = append(g[y], x)
+		deg[x]++
+		deg[y]++
+	}
+
+	leaves := make([]int, 0)
+	for v := 1; v <= n; v++ {
+		if deg[v] == 1 {
+			leaves = append(leaves, v)
+		}
+	}
+
+	root := newNode()
+	best := uint64(math.MaxUint64)
+
+	for i, v := range leaves {
+		if i > 0 {
+			if u := root.query(label[v]); u != -1 {
+				if x := label[v] ^ label[u]; x < best {
+					best = x
+					a, b = u, v
+				}
+			}
+		}
+		root.insert(label[v], v)
+	}
+
+	return a, b, best
+}
@@ -0,0 +1,48 @@
+package xortrie
+
+import "testing"
+
+func TestMinXORLeafPairSimplePath(t *testing.T) {
+	edges := [][2]int{{1, 2}}
+	label := []uint64{0, 5, 9}
+
+	a, b, xor := MinXORLeafPair(2, edges, label)
+	if a != 1 || b != 2 || xor != 12 {
+		t.Fatalf("MinXORLeafPair() = (%d, %d, %d), want (1, 2, 12)", a, b, xor)
+	}
+}
+
+func TestMinXORLeafPairPicksClosestLabels(t *testing.T) {
+	// Звезда: центр 1 (не лист), листья 2,3,4,5. Пары (2,3) и (4,5) обе дают
+	// XOR 1 (разница в младшем бите); листья обходятся по возрастанию id и
+	// минимум обновляется только при строгом улучшении, поэтому ответ — (2,3).
+	edges := [][2]int{{1, 2}, {1, 3}, {1, 4}, {1, 5}}
+	label := make([]uint64, 6)
+	label[2] = 0b000
+	label[3] = 0b001
+	label[4] = 0b110
+	label[5] = 0b111
+
+	a, b, xor := MinXORLeafPair(5, edges, label)
+	if xor != 1 {
+		t.Fatalf("MinXORLeafPair() xor = %d, want 1", xor)
+	}
+	if !((a == 2 && b == 3) || (a == 3 && b == 2)) {
+		t.Fatalf("MinXORLeafPair() pair = (%d, %d), want (2, 3)", a, b)
+	}
+}
+
+func TestMinXORLeafPairIgnoresInternalVertices(t *testing.T) {
+	// 1 -- 2 -- 3: только 1 и 3 — листья, у 2 (внутренней вершины) метка
+	// не должна влиять на ответ, даже если она ближе к одной из сторон.
+	edges := [][2]int{{1, 2}, {2, 3}}
+	label := []uint64{0, 100, 100, 101}
+
+	a, b, xor := MinXORLeafPair(3, edges, label)
+	if !((a == 1 && b == 3) || (a == 3 && b == 1)) {
+		t.Fatalf("MinXORLeafPair() pair = (%d, %d), want (1, 3)", a, b)
+	}
+	if want := label[1] ^ label[3]; xor != want {
+		t.Fatalf("MinXORLeafPair() xor = %d, want %d", xor, want)
+	}
+}